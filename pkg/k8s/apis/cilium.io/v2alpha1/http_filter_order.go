@@ -0,0 +1,154 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Cilium
+
+package v2alpha1
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Well-known names of Envoy HTTP filters that every CiliumEnvoyHTTPFilter
+// resource in the cluster shares an ordering convention for. These mirror
+// the filter names shipped by Envoy itself.
+const (
+	HTTPFilterRouter   = "envoy.filters.http.router"
+	HTTPFilterJWTAuthn = "envoy.filters.http.jwt_authn"
+	HTTPFilterRBAC     = "envoy.filters.http.rbac"
+	HTTPFilterExtAuthz = "envoy.filters.http.ext_authz"
+)
+
+// wellKnownOrder is the canonical relative ordering of the well-known
+// filters above: authentication runs before authorization, and the router
+// is always terminal. Filters that aren't well-known are placed between
+// ext_authz and the router, in the order they are resolved.
+var wellKnownOrder = map[string]int{
+	HTTPFilterJWTAuthn: 0,
+	HTTPFilterRBAC:     1,
+	HTTPFilterExtAuthz: 2,
+	HTTPFilterRouter:   100,
+}
+
+// ValidatePlacement rejects HTTPFilter configurations that place a filter
+// after the terminal envoy.filters.http.router filter, which Envoy itself
+// will refuse to load. It is intended to be called from the
+// CiliumEnvoyHTTPFilter validating admission webhook.
+func (s *CiliumEnvoyHTTPFilterSpec) ValidatePlacement() error {
+	for _, f := range s.HTTPFilters {
+		if f.Name == HTTPFilterRouter {
+			continue
+		}
+		if f.InsertAfter == HTTPFilterRouter {
+			return fmt.Errorf("HTTPFilter %q cannot be inserted after %q", f.Name, HTTPFilterRouter)
+		}
+		if f.InsertBefore != "" && f.InsertBefore == f.Name {
+			return fmt.Errorf("HTTPFilter %q cannot be inserted relative to itself", f.Name)
+		}
+		if f.InsertAfter != "" && f.InsertAfter == f.Name {
+			return fmt.Errorf("HTTPFilter %q cannot be inserted relative to itself", f.Name)
+		}
+	}
+	return nil
+}
+
+// ResolveHTTPFilterOrder computes the canonical HTTP connection manager
+// filter chain order across all HTTPFilters contributed by a set of
+// CiliumEnvoyHTTPFilter resources selected for the same listener.
+//
+// Filters that set InsertBefore/InsertAfter are placed relative to that
+// named filter; everything else is ordered by Priority (lowest first). A
+// filter without a Priority takes its well-known rank instead (jwt_authn 0,
+// rbac 1, ext_authz 2, any other filter 3), and ties keep resolution order.
+// envoy.filters.http.router, if present, is always placed last; a filter
+// with InsertAfter set to the router is an error.
+func ResolveHTTPFilterOrder(filters []*HTTPFilter) ([]*HTTPFilter, error) {
+	ordered := make([]*HTTPFilter, 0, len(filters))
+	var router *HTTPFilter
+	pending := make([]*HTTPFilter, 0, len(filters))
+
+	for _, f := range filters {
+		switch {
+		case f.Name == HTTPFilterRouter:
+			router = f
+		case f.InsertBefore != "" || f.InsertAfter != "":
+			pending = append(pending, f)
+		default:
+			ordered = append(ordered, f)
+		}
+	}
+
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return rank(ordered[i]) < rank(ordered[j])
+	})
+
+	// Router is a valid InsertBefore anchor (it's always terminal: nothing
+	// is placed after it), so it must already be part of the working
+	// set before relative filters are resolved against it.
+	if router != nil {
+		ordered = append(ordered, router)
+	}
+
+	// Relative filters may anchor on another relative filter that hasn't
+	// been placed yet, so resolve to a fixed point instead of a single pass.
+	for len(pending) > 0 {
+		var unresolved []*HTTPFilter
+		progressed := false
+
+		for _, f := range pending {
+			var anchor string
+			var before bool
+			if f.InsertBefore != "" {
+				anchor, before = f.InsertBefore, true
+			} else {
+				anchor, before = f.InsertAfter, false
+			}
+			if !before && anchor == HTTPFilterRouter {
+				return nil, fmt.Errorf("HTTPFilter %q cannot be inserted after %q", f.Name, HTTPFilterRouter)
+			}
+
+			idx := indexByName(ordered, anchor)
+			if idx == -1 {
+				unresolved = append(unresolved, f)
+				continue
+			}
+			if before {
+				ordered = append(ordered[:idx], append([]*HTTPFilter{f}, ordered[idx:]...)...)
+			} else {
+				ordered = append(ordered[:idx+1], append([]*HTTPFilter{f}, ordered[idx+1:]...)...)
+			}
+			progressed = true
+		}
+
+		if !progressed {
+			f := unresolved[0]
+			anchor := f.InsertBefore
+			if anchor == "" {
+				anchor = f.InsertAfter
+			}
+			return nil, fmt.Errorf("HTTPFilter %q references unknown filter %q", f.Name, anchor)
+		}
+		pending = unresolved
+	}
+
+	return ordered, nil
+}
+
+func indexByName(filters []*HTTPFilter, name string) int {
+	for i, f := range filters {
+		if f.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// rank returns the sort key for a filter that doesn't use InsertBefore/InsertAfter.
+func rank(f *HTTPFilter) int {
+	if f.Priority != nil {
+		return *f.Priority
+	}
+	if r, ok := wellKnownOrder[f.Name]; ok {
+		return r
+	}
+	return wellKnownOrder[HTTPFilterExtAuthz] + 1
+}
@@ -0,0 +1,137 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Cilium
+
+package v2alpha1
+
+import "testing"
+
+func filterNames(fs []*HTTPFilter) []string {
+	out := make([]string, len(fs))
+	for i, f := range fs {
+		out[i] = f.Name
+	}
+	return out
+}
+
+func equalNames(got []*HTTPFilter, want []string) bool {
+	gotNames := filterNames(got)
+	if len(gotNames) != len(want) {
+		return false
+	}
+	for i := range want {
+		if gotNames[i] != want[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestResolveHTTPFilterOrder(t *testing.T) {
+	tests := []struct {
+		name    string
+		filters []*HTTPFilter
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "well-known filters sort jwt_authn before rbac before router",
+			filters: []*HTTPFilter{
+				{Name: HTTPFilterRBAC},
+				{Name: HTTPFilterRouter},
+				{Name: HTTPFilterJWTAuthn},
+			},
+			want: []string{HTTPFilterJWTAuthn, HTTPFilterRBAC, HTTPFilterRouter},
+		},
+		{
+			name: "insertBefore router places a filter last before the terminal router",
+			filters: []*HTTPFilter{
+				{Name: HTTPFilterJWTAuthn},
+				{Name: HTTPFilterRouter},
+				{Name: "my.custom.filter", InsertBefore: HTTPFilterRouter},
+			},
+			want: []string{HTTPFilterJWTAuthn, "my.custom.filter", HTTPFilterRouter},
+		},
+		{
+			name: "a chain of relative filters anchored on one another resolves in order",
+			filters: []*HTTPFilter{
+				{Name: "a"},
+				{Name: "c", InsertAfter: "b"},
+				{Name: "b", InsertAfter: "a"},
+			},
+			want: []string{"a", "b", "c"},
+		},
+		{
+			name: "priority overrides well-known ordering",
+			filters: []*HTTPFilter{
+				{Name: HTTPFilterRBAC, Priority: intPtr(-1)},
+				{Name: HTTPFilterJWTAuthn},
+			},
+			want: []string{HTTPFilterRBAC, HTTPFilterJWTAuthn},
+		},
+		{
+			name: "unresolvable anchor is an error",
+			filters: []*HTTPFilter{
+				{Name: "a", InsertBefore: "does.not.exist"},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ResolveHTTPFilterOrder(tt.filters)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got order %v", filterNames(got))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !equalNames(got, tt.want) {
+				t.Fatalf("got %v, want %v", filterNames(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestValidatePlacement(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    CiliumEnvoyHTTPFilterSpec
+		wantErr bool
+	}{
+		{
+			name: "insertBefore router is allowed",
+			spec: CiliumEnvoyHTTPFilterSpec{HTTPFilters: []*HTTPFilter{
+				{Name: "a", InsertBefore: HTTPFilterRouter},
+			}},
+		},
+		{
+			name: "insertAfter router is rejected",
+			spec: CiliumEnvoyHTTPFilterSpec{HTTPFilters: []*HTTPFilter{
+				{Name: "a", InsertAfter: HTTPFilterRouter},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "self-referential placement is rejected",
+			spec: CiliumEnvoyHTTPFilterSpec{HTTPFilters: []*HTTPFilter{
+				{Name: "a", InsertBefore: "a"},
+			}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.spec.ValidatePlacement()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidatePlacement() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func intPtr(i int) *int { return &i }
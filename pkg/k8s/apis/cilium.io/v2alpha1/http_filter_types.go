@@ -55,9 +55,14 @@ type CiliumEnvoyHTTPFilterSpec struct {
 	HTTPFilters []*HTTPFilter `json:"httpFilters,omitempty"`
 }
 
-// HTTPFilter is an Envoy extensions.filters.network.http_connection_manager.v3.HttpFilter
+// HTTPFilter is an Envoy extensions.filters.network.http_connection_manager.v3.HttpFilter.
+// Its filter-specific configuration can be supplied three ways: a hand-authored TypedConfig
+// Any, a ConfigDiscovery source, or (for the handful of filters covered by TypedHTTPFilter) the
+// friendly Typed shortcut, which spares callers from learning Envoy's Any/type_url encoding.
 //
-// +kubebuilder:validation:XValidation:message="HTTPFilter must have exactly 1 of typedConfig or configDiscovery",rule="(has(self.typedConfig) || has(self.configDiscovery)) && !(has(self.typedConfig) && has(self.configDiscovery))"
+
+// +kubebuilder:validation:XValidation:message="HTTPFilter must have exactly 1 of typedConfig, configDiscovery or typed",rule="(has(self.typedConfig) || has(self.configDiscovery) || has(self.typed)) && !(has(self.typedConfig) && has(self.configDiscovery)) && !(has(self.typedConfig) && has(self.typed)) && !(has(self.configDiscovery) && has(self.typed))"
+// +kubebuilder:validation:XValidation:message="HTTPFilter may set at most 1 of insertBefore, insertAfter or priority",rule="[has(self.insertBefore), has(self.insertAfter), has(self.priority)].filter(x, x).size() <= 1"
 type HTTPFilter struct {
 	// Name is the name of the filter configuration.
 	//
@@ -73,6 +78,11 @@ type HTTPFilter struct {
 	//
 	// +kubebuilder:validation:Optional
 	ConfigDiscovery ExtensionConfigSource `json:"configDiscovery,omitempty"`
+	// Typed selects one of TypedHTTPFilter's common-filter shortcuts. Mutually exclusive with
+	// TypedConfig and ConfigDiscovery; TypedHTTPFilter.ToTypedConfig converts it into a TypedConfig.
+	//
+	// +kubebuilder:validation:Optional
+	Typed *TypedHTTPFilter `json:"typed,omitempty"`
 	// IsOptional, if set to true, allows clients that do not support this filter to ignore the filter but otherwise accept the config. Otherwise, clients that do not support this filter must reject the config.
 	//
 	// +kubebuilder:validation:Optional
@@ -81,6 +91,22 @@ type HTTPFilter struct {
 	//
 	// +kubebuilder:validation:Optional
 	Disabled bool `json:"disabled"`
+	// InsertBefore places this filter immediately before the named filter in the HTTP connection
+	// manager filter chain. Mutually exclusive with InsertAfter and Priority.
+	//
+	// +kubebuilder:validation:Optional
+	InsertBefore string `json:"insertBefore,omitempty"`
+	// InsertAfter places this filter immediately after the named filter in the HTTP connection
+	// manager filter chain. Mutually exclusive with InsertBefore and Priority.
+	//
+	// +kubebuilder:validation:Optional
+	InsertAfter string `json:"insertAfter,omitempty"`
+	// Priority orders filters that don't specify InsertBefore/InsertAfter relative to one another,
+	// lowest first. Filters with equal priority keep the order in which their CiliumEnvoyHTTPFilter
+	// resources were resolved. Mutually exclusive with InsertBefore and InsertAfter.
+	//
+	// +kubebuilder:validation:Optional
+	Priority *int `json:"priority,omitempty"`
 }
 
 // TypedConfig is a stand-in for Envoy's HTTP Filter typed_config
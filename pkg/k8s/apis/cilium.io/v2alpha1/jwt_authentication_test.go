@@ -0,0 +1,94 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Cilium
+
+package v2alpha1
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCiliumJWTAuthenticationSpecToHTTPFilter(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    CiliumJWTAuthenticationSpec
+		wantErr bool
+	}{
+		{
+			name: "local JWKS provider with a path prefix rule",
+			spec: CiliumJWTAuthenticationSpec{
+				Providers: []JWTProvider{
+					{
+						Name:       "local",
+						Issuer:     "https://issuer.example.com",
+						Audiences:  []string{"my-api"},
+						JwksSource: JWKSSource{LocalJWKS: &LocalJWKS{Inline: `{"keys":[]}`}},
+					},
+				},
+				Rules: []JWTRequirementRule{
+					{PathPrefix: "/api", RequiredProviderName: "local"},
+				},
+			},
+		},
+		{
+			name: "remote JWKS provider with an exact path rule",
+			spec: CiliumJWTAuthenticationSpec{
+				Providers: []JWTProvider{
+					{
+						Name: "remote",
+						JwksSource: JWKSSource{RemoteJWKS: &RemoteJWKS{
+							URI:           "https://issuer.example.com/.well-known/jwks.json",
+							ClusterName:   "jwks-cluster",
+							CacheDuration: &metav1.Duration{Duration: 10 * time.Minute},
+						}},
+					},
+				},
+				Rules: []JWTRequirementRule{
+					{PathExact: "/healthz", RequiredProviderName: "remote"},
+				},
+			},
+		},
+		{
+			name: "provider with neither JWKS source errors",
+			spec: CiliumJWTAuthenticationSpec{
+				Providers: []JWTProvider{{Name: "broken"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "rule with neither path match errors",
+			spec: CiliumJWTAuthenticationSpec{
+				Providers: []JWTProvider{{
+					Name:       "local",
+					JwksSource: JWKSSource{LocalJWKS: &LocalJWKS{Inline: `{}`}},
+				}},
+				Rules: []JWTRequirementRule{{RequiredProviderName: "local"}},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := tt.spec.ToHTTPFilter("jwt-authn")
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if f.Name != "jwt-authn" {
+				t.Fatalf("got name %q, want %q", f.Name, "jwt-authn")
+			}
+			const wantTypeURL = "type.googleapis.com/envoy.extensions.filters.http.jwt_authn.v3.JwtAuthentication"
+			if f.TypedConfig.Any.TypeUrl != wantTypeURL {
+				t.Fatalf("got type URL %q, want %q", f.TypedConfig.Any.TypeUrl, wantTypeURL)
+			}
+		})
+	}
+}
@@ -0,0 +1,241 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Cilium
+
+package v2alpha1
+
+import (
+	"fmt"
+	"time"
+
+	corev3 "github.com/cilium/proxy/go/envoy/config/core/v3"
+	routev3 "github.com/cilium/proxy/go/envoy/config/route/v3"
+	jwt_authnv3 "github.com/cilium/proxy/go/envoy/extensions/filters/http/jwt_authn/v3"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:resource:categories={cilium},singular="ciliumjwtauthentication",path="ciliumjwtauthentications",scope="Namespaced",shortName={cjwtauth}
+// +kubebuilder:printcolumn:JSONPath=".metadata.creationTimestamp",description="The age of the identity",name="Age",type=date
+// +kubebuilder:storageversion
+
+// CiliumJWTAuthentication renders into an HTTPFilter (see HTTPFilter.TypedConfig) carrying an
+// envoy.filters.http.jwt_authn TypedConfig, for the most commonly requested Envoy HTTP filter.
+type CiliumJWTAuthentication struct {
+	// +k8s:openapi-gen=false
+	// +deepequal-gen=false
+	metav1.TypeMeta `json:",inline"`
+	// +k8s:openapi-gen=false
+	// +deepequal-gen=false
+	metav1.ObjectMeta `json:"metadata"`
+
+	// +k8s:openapi-gen=false
+	Spec CiliumJWTAuthenticationSpec `json:"spec,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +deepequal-gen=false
+
+// CiliumJWTAuthenticationList is a list of CiliumJWTAuthentication objects.
+type CiliumJWTAuthenticationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	// Items is a list of CiliumJWTAuthentication.
+	Items []CiliumJWTAuthentication `json:"items"`
+}
+
+type CiliumJWTAuthenticationSpec struct {
+	// Providers is the set of JWT providers that requests may be authenticated against.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	Providers []JWTProvider `json:"providers"`
+
+	// Rules maps path prefixes/exact matches to the provider(s) required to satisfy them.
+	//
+	// +kubebuilder:validation:Optional
+	Rules []JWTRequirementRule `json:"rules,omitempty"`
+}
+
+// JWTProvider is the Cilium equivalent of Envoy's JwtProvider, restricted to
+// the fields users actually need to configure (Issuer, Audiences and the
+// JWKS source).
+type JWTProvider struct {
+	// Name is the name of the provider, referenced from JWTRequirementRule.RequiredProviderName.
+	//
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Issuer is the principal that issued the JWT, matched against the "iss" claim.
+	// If unset, the issuer is not checked.
+	//
+	// +kubebuilder:validation:Optional
+	Issuer string `json:"issuer,omitempty"`
+
+	// Audiences is the list of JWT audiences that are allowed to access. If unset,
+	// the "aud" claim is not checked.
+	//
+	// +kubebuilder:validation:Optional
+	Audiences []string `json:"audiences,omitempty"`
+
+	// JwksSource specifies where to fetch the JSON Web Key Set used to verify the JWT signature.
+	//
+	// +kubebuilder:validation:Required
+	JwksSource JWKSSource `json:"jwksSource"`
+}
+
+// JWKSSource is a oneOf between an inline JWKS document and one fetched from a remote endpoint.
+//
+// +kubebuilder:validation:XValidation:message="JWKSSource must have exactly 1 of localJWKS or remoteJWKS",rule="(has(self.localJWKS) || has(self.remoteJWKS)) && !(has(self.localJWKS) && has(self.remoteJWKS))"
+type JWKSSource struct {
+	// LocalJWKS is a JWKS document inlined directly into the CRD.
+	//
+	// +kubebuilder:validation:Optional
+	LocalJWKS *LocalJWKS `json:"localJWKS,omitempty"`
+
+	// RemoteJWKS fetches the JWKS document from an Envoy cluster over HTTP.
+	//
+	// +kubebuilder:validation:Optional
+	RemoteJWKS *RemoteJWKS `json:"remoteJWKS,omitempty"`
+}
+
+// LocalJWKS is a raw JWKS document supplied inline.
+type LocalJWKS struct {
+	// Inline is the raw JWKS JSON document.
+	//
+	// +kubebuilder:validation:Required
+	Inline string `json:"inline"`
+}
+
+// RemoteJWKS fetches the JWKS document from a remote HTTP endpoint via an Envoy cluster.
+type RemoteJWKS struct {
+	// URI is the URI of the JWKS document.
+	//
+	// +kubebuilder:validation:Required
+	URI string `json:"uri"`
+
+	// ClusterName is the Envoy cluster that will be used to fetch the JWKS document.
+	//
+	// +kubebuilder:validation:Required
+	ClusterName string `json:"clusterName"`
+
+	// CacheDuration is how long to cache the fetched JWKS for. Defaults to 5 minutes.
+	//
+	// +kubebuilder:validation:Optional
+	CacheDuration *metav1.Duration `json:"cacheDuration,omitempty"`
+
+	// Timeout is the timeout for the fetch request. Defaults to 5 seconds.
+	//
+	// +kubebuilder:validation:Optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// JWTRequirementRule maps a path match to the provider that must authenticate it.
+//
+// +kubebuilder:validation:XValidation:message="JWTRequirementRule must have exactly 1 of pathPrefix or pathExact",rule="(has(self.pathPrefix) || has(self.pathExact)) && !(has(self.pathPrefix) && has(self.pathExact))"
+type JWTRequirementRule struct {
+	// PathPrefix matches all paths starting with this prefix.
+	//
+	// +kubebuilder:validation:Optional
+	PathPrefix string `json:"pathPrefix,omitempty"`
+
+	// PathExact matches this path exactly.
+	//
+	// +kubebuilder:validation:Optional
+	PathExact string `json:"pathExact,omitempty"`
+
+	// RequiredProviderName is the name of the JWTProvider that must successfully
+	// authenticate a request matching this rule.
+	//
+	// +kubebuilder:validation:Required
+	RequiredProviderName string `json:"requiredProviderName"`
+}
+
+// ToHTTPFilter renders the CiliumJWTAuthentication spec into an HTTPFilter
+// carrying a jwt_authn TypedConfig, for injection into a
+// CiliumEnvoyHTTPFilterSpec.HTTPFilters chain.
+func (s *CiliumJWTAuthenticationSpec) ToHTTPFilter(name string) (*HTTPFilter, error) {
+	jwtAuthn := &jwt_authnv3.JwtAuthentication{
+		Providers: make(map[string]*jwt_authnv3.JwtProvider, len(s.Providers)),
+	}
+
+	for _, p := range s.Providers {
+		provider := &jwt_authnv3.JwtProvider{
+			Issuer:    p.Issuer,
+			Audiences: p.Audiences,
+		}
+
+		switch {
+		case p.JwksSource.LocalJWKS != nil:
+			provider.JwksSourceSpecifier = &jwt_authnv3.JwtProvider_LocalJwks{
+				LocalJwks: &corev3.DataSource{
+					Specifier: &corev3.DataSource_InlineString{
+						InlineString: p.JwksSource.LocalJWKS.Inline,
+					},
+				},
+			}
+		case p.JwksSource.RemoteJWKS != nil:
+			r := p.JwksSource.RemoteJWKS
+			cacheDuration := durationpb.New(5 * time.Minute)
+			if r.CacheDuration != nil {
+				cacheDuration = durationpb.New(r.CacheDuration.Duration)
+			}
+			timeout := durationpb.New(5 * time.Second)
+			if r.Timeout != nil {
+				timeout = durationpb.New(r.Timeout.Duration)
+			}
+			provider.JwksSourceSpecifier = &jwt_authnv3.JwtProvider_RemoteJwks{
+				RemoteJwks: &jwt_authnv3.RemoteJwks{
+					HttpUri: &corev3.HttpUri{
+						Uri:     r.URI,
+						Timeout: timeout,
+						HttpUpstreamType: &corev3.HttpUri_Cluster{
+							Cluster: r.ClusterName,
+						},
+					},
+					CacheDuration: cacheDuration,
+				},
+			}
+		default:
+			return nil, fmt.Errorf("provider %q has neither localJWKS nor remoteJWKS set", p.Name)
+		}
+
+		jwtAuthn.Providers[p.Name] = provider
+	}
+
+	for _, rule := range s.Rules {
+		match := &routev3.RouteMatch{}
+		switch {
+		case rule.PathPrefix != "":
+			match.PathSpecifier = &routev3.RouteMatch_Prefix{Prefix: rule.PathPrefix}
+		case rule.PathExact != "":
+			match.PathSpecifier = &routev3.RouteMatch_Path{Path: rule.PathExact}
+		default:
+			return nil, fmt.Errorf("rule for provider %q has neither pathPrefix nor pathExact set", rule.RequiredProviderName)
+		}
+
+		jwtAuthn.Rules = append(jwtAuthn.Rules, &jwt_authnv3.RequirementRule{
+			Match: match,
+			RequirementType: &jwt_authnv3.RequirementRule_Requires{
+				Requires: &jwt_authnv3.JwtRequirement{
+					RequiresType: &jwt_authnv3.JwtRequirement_ProviderName{
+						ProviderName: rule.RequiredProviderName,
+					},
+				},
+			},
+		})
+	}
+
+	payload, err := anypb.New(jwtAuthn)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling jwt_authn TypedConfig: %w", err)
+	}
+
+	return &HTTPFilter{
+		Name:        name,
+		TypedConfig: TypedConfig{Any: payload},
+	}, nil
+}
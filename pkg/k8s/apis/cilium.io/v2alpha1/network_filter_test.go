@@ -0,0 +1,53 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Cilium
+
+package v2alpha1
+
+import (
+	"testing"
+
+	jwt_authnv3 "github.com/cilium/proxy/go/envoy/extensions/filters/http/jwt_authn/v3"
+	tcp_proxyv3 "github.com/cilium/proxy/go/envoy/extensions/filters/network/tcp_proxy/v3"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+func TestNetworkFilterValidate(t *testing.T) {
+	tcpProxy, err := anypb.New(&tcp_proxyv3.TcpProxy{StatPrefix: "ingress"})
+	if err != nil {
+		t.Fatalf("anypb.New(TcpProxy): %v", err)
+	}
+	httpOnly, err := anypb.New(&jwt_authnv3.JwtAuthentication{})
+	if err != nil {
+		t.Fatalf("anypb.New(JwtAuthentication): %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		filter  NetworkFilter
+		wantErr bool
+	}{
+		{
+			name:   "tcp_proxy is accepted",
+			filter: NetworkFilter{Name: "tcp", TypedConfig: TypedConfig{Any: tcpProxy}},
+		},
+		{
+			name:    "an HTTP-only filter type is rejected from the network allow-list",
+			filter:  NetworkFilter{Name: "jwt", TypedConfig: TypedConfig{Any: httpOnly}},
+			wantErr: true,
+		},
+		{
+			name:    "empty typed_config is rejected",
+			filter:  NetworkFilter{Name: "empty"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.filter.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
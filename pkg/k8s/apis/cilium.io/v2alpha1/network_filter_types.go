@@ -0,0 +1,100 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Cilium
+
+package v2alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:resource:categories={cilium},singular="ciliumenvoynetworkfilter",path="ciliumenvoynetworkfilters",scope="Namespaced",shortName={cenf}
+// +kubebuilder:printcolumn:JSONPath=".metadata.creationTimestamp",description="The age of the identity",name="Age",type=date
+// +kubebuilder:storageversion
+
+type CiliumEnvoyNetworkFilter struct {
+	// +k8s:openapi-gen=false
+	// +deepequal-gen=false
+	metav1.TypeMeta `json:",inline"`
+	// +k8s:openapi-gen=false
+	// +deepequal-gen=false
+	metav1.ObjectMeta `json:"metadata"`
+
+	// +k8s:openapi-gen=false
+	Spec CiliumEnvoyNetworkFilterSpec `json:"spec,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +deepequal-gen=false
+
+// CiliumEnvoyNetworkFilterList is a list of CiliumEnvoyNetworkFilter objects.
+type CiliumEnvoyNetworkFilterList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	// Items is a list of CiliumEnvoyNetworkFilter.
+	Items []CiliumEnvoyNetworkFilter `json:"items"`
+}
+
+// CiliumEnvoyNetworkFilterSpec selects the listener filter chain(s) this
+// filter is spliced into, and lists the network filters to insert.
+//
+// +kubebuilder:validation:XValidation:message="CiliumEnvoyNetworkFilterSpec must have exactly 1 of listenerName or listenerSelector",rule="(has(self.listenerName) || has(self.listenerSelector)) && !(has(self.listenerName) && has(self.listenerSelector))"
+type CiliumEnvoyNetworkFilterSpec struct {
+	// ListenerName selects a single Cilium Envoy listener by name to splice these
+	// NetworkFilters into.
+	//
+	// +kubebuilder:validation:Optional
+	ListenerName string `json:"listenerName,omitempty"`
+
+	// ListenerSelector selects Cilium Envoy listeners by the labels attached to
+	// their metadata, for splicing these NetworkFilters into more than one
+	// listener's filter chain at once.
+	//
+	// +kubebuilder:validation:Optional
+	ListenerSelector *metav1.LabelSelector `json:"listenerSelector,omitempty"`
+
+	// NetworkFilters is a list of NetworkFilter to be inserted into the
+	// selected listener's filter chain.
+	//
+	// +kubebuilder:validation:Optional
+	NetworkFilters []*NetworkFilter `json:"networkFilters,omitempty"`
+}
+
+// NetworkFilter is an Envoy config.listener.v3.Filter, for non-HTTP
+// listener filter chains (e.g. tcp_proxy, mongo_proxy, redis_proxy,
+// thrift_proxy, ratelimit, ext_authz TCP, wasm network filter).
+//
+// +kubebuilder:validation:XValidation:message="NetworkFilter must have exactly 1 of typedConfig or configDiscovery",rule="(has(self.typedConfig) || has(self.configDiscovery)) && !(has(self.typedConfig) && has(self.configDiscovery))"
+type NetworkFilter struct {
+	// Name is the name of the filter configuration.
+	//
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+	// TypedConfig is filter specific configuration which depends on the filter being instantiated.
+	//
+	// +kubebuilder:validation:Optional
+	TypedConfig TypedConfig `json:"typedConfig,omitempty"`
+	// ConfigDiscovery is a configuration source specifier for an extension configuration discovery service.
+	//
+	// Warning: Note that this is not validated extensively for now.
+	//
+	// +kubebuilder:validation:Optional
+	ConfigDiscovery ExtensionConfigSource `json:"configDiscovery,omitempty"`
+}
+
+// Validate decodes the NetworkFilter's TypedConfig against the network
+// filter-chain allow-list (tcp_proxy, mongo_proxy, redis_proxy,
+// thrift_proxy, ratelimit, ext_authz, wasm) and runs its generated
+// validation rules. Unlike HTTPFilter, a NetworkFilter's typed_config is
+// never an HTTP filter message, so it is validated against
+// networkFilterTypeAllowList rather than TypedConfig.Validate's HTTP list.
+// Without a TypedConfig, ConfigDiscovery is validated as an
+// ExtensionConfigSource (see ExtensionConfigSource.Validate).
+func (f *NetworkFilter) Validate() error {
+	if f.TypedConfig.Any != nil {
+		return validateAny(f.TypedConfig.Any, networkFilterTypeAllowList)
+	}
+	return f.ConfigDiscovery.Validate()
+}
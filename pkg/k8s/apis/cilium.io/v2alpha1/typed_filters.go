@@ -0,0 +1,306 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Cilium
+
+package v2alpha1
+
+import (
+	"fmt"
+	"time"
+
+	corev3 "github.com/cilium/proxy/go/envoy/config/core/v3"
+	corsv3 "github.com/cilium/proxy/go/envoy/extensions/filters/http/cors/v3"
+	ext_authzv3 "github.com/cilium/proxy/go/envoy/extensions/filters/http/ext_authz/v3"
+	header_to_metadatav3 "github.com/cilium/proxy/go/envoy/extensions/filters/http/header_to_metadata/v3"
+	local_ratelimitv3 "github.com/cilium/proxy/go/envoy/extensions/filters/http/local_ratelimit/v3"
+	luav3 "github.com/cilium/proxy/go/envoy/extensions/filters/http/lua/v3"
+	wasmv3 "github.com/cilium/proxy/go/envoy/extensions/filters/http/wasm/v3"
+	extensions_wasmv3 "github.com/cilium/proxy/go/envoy/extensions/wasm/v3"
+	typev3 "github.com/cilium/proxy/go/envoy/type/v3"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TypedHTTPFilter is a discriminated union of friendly, typed shortcuts for the handful of Envoy
+// HTTP filters users actually reach for. See HTTPFilter.Typed for how this fits into a filter.
+//
+// +kubebuilder:validation:XValidation:message="TypedHTTPFilter must have exactly 1 field set",rule="[has(self.lua), has(self.wasm), has(self.localRateLimit), has(self.extAuthz), has(self.cors), has(self.headerToMetadata)].filter(x, x).size() == 1"
+type TypedHTTPFilter struct {
+	// Lua runs an inline Lua script for request/response processing.
+	//
+	// +kubebuilder:validation:Optional
+	Lua *LuaFilter `json:"lua,omitempty"`
+	// Wasm loads a WebAssembly filter from a local file or a remote HTTP source.
+	//
+	// +kubebuilder:validation:Optional
+	Wasm *WasmFilter `json:"wasm,omitempty"`
+	// LocalRateLimit token-bucket rate limits requests at this filter's position in the chain.
+	//
+	// +kubebuilder:validation:Optional
+	LocalRateLimit *LocalRateLimitFilter `json:"localRateLimit,omitempty"`
+	// ExtAuthz delegates the authorization decision to an external gRPC service.
+	//
+	// +kubebuilder:validation:Optional
+	ExtAuthz *ExtAuthzFilter `json:"extAuthz,omitempty"`
+	// CORS enforces Cross-Origin Resource Sharing using the route/virtual host CORS policy.
+	//
+	// +kubebuilder:validation:Optional
+	CORS *CORSFilter `json:"cors,omitempty"`
+	// HeaderToMetadata copies request header values into dynamic metadata.
+	//
+	// +kubebuilder:validation:Optional
+	HeaderToMetadata *HeaderToMetadataFilter `json:"headerToMetadata,omitempty"`
+}
+
+// LuaFilter is the envoy.filters.http.lua shortcut.
+type LuaFilter struct {
+	// InlineCode is the Lua source run for every request.
+	//
+	// +kubebuilder:validation:Required
+	InlineCode string `json:"inlineCode"`
+}
+
+// WasmFilter is the envoy.filters.http.wasm shortcut.
+//
+// +kubebuilder:validation:XValidation:message="WasmFilter must have exactly 1 of localFile or remoteHTTP",rule="(has(self.localFile) || has(self.remoteHTTP)) && !(has(self.localFile) && has(self.remoteHTTP))"
+type WasmFilter struct {
+	// LocalFile is the path to the .wasm module on the Envoy proxy's filesystem.
+	//
+	// +kubebuilder:validation:Optional
+	LocalFile string `json:"localFile,omitempty"`
+	// RemoteHTTP fetches the .wasm module over HTTP(S).
+	//
+	// +kubebuilder:validation:Optional
+	RemoteHTTP *WasmRemoteHTTP `json:"remoteHTTP,omitempty"`
+	// RootID identifies which Wasm VM root context to run, for modules that define more than one.
+	//
+	// +kubebuilder:validation:Optional
+	RootID string `json:"rootID,omitempty"`
+}
+
+// WasmRemoteHTTP fetches a Wasm module from an HTTP(S) URI, verified against SHA256.
+type WasmRemoteHTTP struct {
+	// URI is the HTTP(S) URI the module is fetched from.
+	//
+	// +kubebuilder:validation:Required
+	URI string `json:"uri"`
+	// SHA256 is the expected SHA256 of the fetched module, required so Envoy can detect corruption
+	// or a compromised origin.
+	//
+	// +kubebuilder:validation:Required
+	SHA256 string `json:"sha256"`
+}
+
+// LocalRateLimitFilter is the envoy.filters.http.local_ratelimit shortcut.
+type LocalRateLimitFilter struct {
+	// MaxTokens is the maximum size of the token bucket.
+	//
+	// +kubebuilder:validation:Required
+	MaxTokens uint32 `json:"maxTokens"`
+	// TokensPerFill is the number of tokens added to the bucket on each FillInterval. Defaults to 1.
+	//
+	// +kubebuilder:validation:Optional
+	TokensPerFill uint32 `json:"tokensPerFill,omitempty"`
+	// FillInterval is how often TokensPerFill tokens are added to the bucket.
+	//
+	// +kubebuilder:validation:Required
+	FillInterval metav1.Duration `json:"fillInterval"`
+	// StatusCode is the HTTP status code returned once the bucket is exhausted. Defaults to 429.
+	//
+	// +kubebuilder:validation:Optional
+	StatusCode *uint32 `json:"statusCode,omitempty"`
+	// ResponseHeadersToAdd are extra headers added to the throttled response.
+	//
+	// +kubebuilder:validation:Optional
+	ResponseHeadersToAdd map[string]string `json:"responseHeadersToAdd,omitempty"`
+	// EnabledPercent is the percentage (0-100) of requests the filter runs the token bucket check
+	// for at all. Defaults to 100; Envoy treats an unset value as 0, i.e. the filter never engaging.
+	//
+	// +kubebuilder:validation:Optional
+	EnabledPercent *uint32 `json:"enabledPercent,omitempty"`
+	// EnforcedPercent is the percentage (0-100) of checked requests that are actually rate
+	// limited once the bucket is exhausted, rather than just recorded. Defaults to 100; Envoy
+	// treats an unset value as 0, i.e. the limit never being enforced.
+	//
+	// +kubebuilder:validation:Optional
+	EnforcedPercent *uint32 `json:"enforcedPercent,omitempty"`
+}
+
+// ExtAuthzFilter is the envoy.filters.http.ext_authz shortcut, restricted to the gRPC service case.
+type ExtAuthzFilter struct {
+	// ClusterName is the Envoy cluster serving the authorization gRPC service.
+	//
+	// +kubebuilder:validation:Required
+	ClusterName string `json:"clusterName"`
+	// Timeout is how long to wait for the authorization check before FailureModeAllow applies.
+	//
+	// +kubebuilder:validation:Optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+	// FailureModeAllow, if true, allows the request through when the authorization service is
+	// unreachable or times out, instead of rejecting it.
+	//
+	// +kubebuilder:validation:Optional
+	FailureModeAllow bool `json:"failureModeAllow,omitempty"`
+}
+
+// CORSFilter is the envoy.filters.http.cors shortcut. The actual CORS policy is configured per
+// route/virtual host; this filter just needs to be present in the chain to enforce it.
+type CORSFilter struct{}
+
+// HeaderToMetadataFilter is the envoy.filters.http.header_to_metadata shortcut.
+type HeaderToMetadataFilter struct {
+	// RequestRules copies request header values into request dynamic metadata.
+	//
+	// +kubebuilder:validation:Optional
+	RequestRules []HeaderToMetadataRule `json:"requestRules,omitempty"`
+}
+
+// HeaderToMetadataRule copies the value of HeaderName into dynamic metadata under MetadataKey.
+type HeaderToMetadataRule struct {
+	// HeaderName is the header whose value is copied.
+	//
+	// +kubebuilder:validation:Required
+	HeaderName string `json:"headerName"`
+	// MetadataKey is the dynamic metadata key the header value is written to.
+	//
+	// +kubebuilder:validation:Required
+	MetadataKey string `json:"metadataKey"`
+}
+
+// ToTypedConfig converts the selected shortcut into the Envoy TypedConfig it stands in for.
+func (t *TypedHTTPFilter) ToTypedConfig() (*TypedConfig, error) {
+	var msg *anypb.Any
+	var err error
+
+	switch {
+	case t.Lua != nil:
+		msg, err = anypb.New(&luav3.Lua{
+			DefaultSourceCode: &corev3.DataSource{
+				Specifier: &corev3.DataSource_InlineString{InlineString: t.Lua.InlineCode},
+			},
+		})
+	case t.Wasm != nil:
+		msg, err = anypb.New(&wasmv3.Wasm{Config: t.Wasm.vmConfig()})
+	case t.LocalRateLimit != nil:
+		msg, err = anypb.New(t.LocalRateLimit.toProto())
+	case t.ExtAuthz != nil:
+		msg, err = anypb.New(t.ExtAuthz.toProto())
+	case t.CORS != nil:
+		msg, err = anypb.New(&corsv3.Cors{})
+	case t.HeaderToMetadata != nil:
+		msg, err = anypb.New(t.HeaderToMetadata.toProto())
+	default:
+		return nil, fmt.Errorf("TypedHTTPFilter has no field set")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("marshaling typed HTTP filter: %w", err)
+	}
+
+	return &TypedConfig{Any: msg}, nil
+}
+
+func (w *WasmFilter) vmConfig() *extensions_wasmv3.PluginConfig {
+	cfg := &extensions_wasmv3.PluginConfig{
+		Vm: &extensions_wasmv3.PluginConfig_VmConfig{
+			VmConfig: &extensions_wasmv3.VmConfig{
+				RootId: w.RootID,
+				Code:   &corev3.AsyncDataSource{},
+			},
+		},
+	}
+
+	switch {
+	case w.LocalFile != "":
+		cfg.Vm.VmConfig.Code.Specifier = &corev3.AsyncDataSource_Local{
+			Local: &corev3.DataSource{Specifier: &corev3.DataSource_Filename{Filename: w.LocalFile}},
+		}
+	case w.RemoteHTTP != nil:
+		cfg.Vm.VmConfig.Code.Specifier = &corev3.AsyncDataSource_Remote{
+			Remote: &corev3.RemoteDataSource{
+				HttpUri: &corev3.HttpUri{Uri: w.RemoteHTTP.URI},
+				Sha256:  w.RemoteHTTP.SHA256,
+			},
+		}
+	}
+
+	return cfg
+}
+
+func (r *LocalRateLimitFilter) toProto() *local_ratelimitv3.LocalRateLimit {
+	tokensPerFill := r.TokensPerFill
+	if tokensPerFill == 0 {
+		tokensPerFill = 1
+	}
+	statusCode := uint32(429)
+	if r.StatusCode != nil {
+		statusCode = *r.StatusCode
+	}
+
+	filter := &local_ratelimitv3.LocalRateLimit{
+		TokenBucket: &typev3.TokenBucket{
+			MaxTokens:     r.MaxTokens,
+			TokensPerFill: wrapperspb.UInt32(tokensPerFill),
+			FillInterval:  durationpb.New(r.FillInterval.Duration),
+		},
+		Status: &typev3.HttpStatus{Code: typev3.StatusCode(statusCode)},
+		// Envoy defaults both of these to 0% if left unset, which silently turns the filter into
+		// a no-op, so default them to fully enabled/enforced here rather than relying on callers
+		// to know that.
+		FilterEnabled:  runtimeFractionalPercent(r.EnabledPercent),
+		FilterEnforced: runtimeFractionalPercent(r.EnforcedPercent),
+	}
+	for k, v := range r.ResponseHeadersToAdd {
+		filter.ResponseHeadersToAdd = append(filter.ResponseHeadersToAdd, &corev3.HeaderValueOption{
+			Header: &corev3.HeaderValue{Key: k, Value: v},
+		})
+	}
+	return filter
+}
+
+// runtimeFractionalPercent renders pct (0-100, defaulting to 100) as the
+// RuntimeFractionalPercent Envoy's local_ratelimit filter expects for both
+// FilterEnabled and FilterEnforced.
+func runtimeFractionalPercent(pct *uint32) *corev3.RuntimeFractionalPercent {
+	numerator := uint32(100)
+	if pct != nil {
+		numerator = *pct
+	}
+	return &corev3.RuntimeFractionalPercent{
+		DefaultValue: &typev3.FractionalPercent{
+			Numerator:   numerator,
+			Denominator: typev3.FractionalPercent_HUNDRED,
+		},
+	}
+}
+
+func (e *ExtAuthzFilter) toProto() *ext_authzv3.ExtAuthz {
+	timeout := durationpb.New(200 * time.Millisecond)
+	if e.Timeout != nil {
+		timeout = durationpb.New(e.Timeout.Duration)
+	}
+	return &ext_authzv3.ExtAuthz{
+		Services: &ext_authzv3.ExtAuthz_GrpcService{
+			GrpcService: &corev3.GrpcService{
+				TargetSpecifier: &corev3.GrpcService_EnvoyGrpc_{
+					EnvoyGrpc: &corev3.GrpcService_EnvoyGrpc{ClusterName: e.ClusterName},
+				},
+				Timeout: timeout,
+			},
+		},
+		FailureModeAllow: e.FailureModeAllow,
+	}
+}
+
+func (h *HeaderToMetadataFilter) toProto() *header_to_metadatav3.Config {
+	cfg := &header_to_metadatav3.Config{}
+	for _, rule := range h.RequestRules {
+		cfg.RequestRules = append(cfg.RequestRules, &header_to_metadatav3.Config_Rule{
+			Header: rule.HeaderName,
+			OnHeaderPresent: &header_to_metadatav3.Config_KeyValuePair{
+				Key: rule.MetadataKey,
+			},
+		})
+	}
+	return cfg
+}
@@ -0,0 +1,135 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Cilium
+
+package v2alpha1
+
+import (
+	"testing"
+
+	local_ratelimitv3 "github.com/cilium/proxy/go/envoy/extensions/filters/http/local_ratelimit/v3"
+	typev3 "github.com/cilium/proxy/go/envoy/type/v3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestTypedHTTPFilterToTypedConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		filter      TypedHTTPFilter
+		wantTypeURL string
+		wantErr     bool
+	}{
+		{
+			name:        "lua",
+			filter:      TypedHTTPFilter{Lua: &LuaFilter{InlineCode: "function envoy_on_request(h) end"}},
+			wantTypeURL: "type.googleapis.com/envoy.extensions.filters.http.lua.v3.Lua",
+		},
+		{
+			name:        "wasm local file",
+			filter:      TypedHTTPFilter{Wasm: &WasmFilter{LocalFile: "/etc/envoy/filter.wasm"}},
+			wantTypeURL: "type.googleapis.com/envoy.extensions.filters.http.wasm.v3.Wasm",
+		},
+		{
+			name: "wasm remote http",
+			filter: TypedHTTPFilter{Wasm: &WasmFilter{RemoteHTTP: &WasmRemoteHTTP{
+				URI:    "https://example.com/filter.wasm",
+				SHA256: "deadbeef",
+			}}},
+			wantTypeURL: "type.googleapis.com/envoy.extensions.filters.http.wasm.v3.Wasm",
+		},
+		{
+			name: "local rate limit",
+			filter: TypedHTTPFilter{LocalRateLimit: &LocalRateLimitFilter{
+				MaxTokens:    10,
+				FillInterval: metav1.Duration{Duration: 1e9},
+			}},
+			wantTypeURL: "type.googleapis.com/envoy.extensions.filters.http.local_ratelimit.v3.LocalRateLimit",
+		},
+		{
+			name:        "ext authz",
+			filter:      TypedHTTPFilter{ExtAuthz: &ExtAuthzFilter{ClusterName: "authz-cluster"}},
+			wantTypeURL: "type.googleapis.com/envoy.extensions.filters.http.ext_authz.v3.ExtAuthz",
+		},
+		{
+			name:        "cors",
+			filter:      TypedHTTPFilter{CORS: &CORSFilter{}},
+			wantTypeURL: "type.googleapis.com/envoy.extensions.filters.http.cors.v3.Cors",
+		},
+		{
+			name: "header to metadata",
+			filter: TypedHTTPFilter{HeaderToMetadata: &HeaderToMetadataFilter{
+				RequestRules: []HeaderToMetadataRule{{HeaderName: "x-user", MetadataKey: "user"}},
+			}},
+			wantTypeURL: "type.googleapis.com/envoy.extensions.filters.http.header_to_metadata.v3.Config",
+		},
+		{
+			name:    "no field set errors",
+			filter:  TypedHTTPFilter{},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.filter.ToTypedConfig()
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.Any.TypeUrl != tt.wantTypeURL {
+				t.Fatalf("got type URL %q, want %q", got.Any.TypeUrl, tt.wantTypeURL)
+			}
+		})
+	}
+}
+
+// TestLocalRateLimitDefaultsToFullyEnabled guards against the filter being
+// installed but never actually enforcing: Envoy treats an unset
+// FilterEnabled/FilterEnforced as 0%, silently turning local_ratelimit into
+// a no-op.
+func TestLocalRateLimitDefaultsToFullyEnabled(t *testing.T) {
+	tc, err := (&TypedHTTPFilter{LocalRateLimit: &LocalRateLimitFilter{
+		MaxTokens:    5,
+		FillInterval: metav1.Duration{Duration: 1e9},
+	}}).ToTypedConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var lrl local_ratelimitv3.LocalRateLimit
+	if err := tc.Any.UnmarshalTo(&lrl); err != nil {
+		t.Fatalf("unmarshaling LocalRateLimit: %v", err)
+	}
+
+	if got := lrl.FilterEnabled.GetDefaultValue().GetNumerator(); got != 100 {
+		t.Errorf("FilterEnabled numerator = %d, want 100", got)
+	}
+	if got := lrl.FilterEnforced.GetDefaultValue().GetNumerator(); got != 100 {
+		t.Errorf("FilterEnforced numerator = %d, want 100", got)
+	}
+
+	overridden, err := (&TypedHTTPFilter{LocalRateLimit: &LocalRateLimitFilter{
+		MaxTokens:      5,
+		FillInterval:   metav1.Duration{Duration: 1e9},
+		EnabledPercent: uint32Ptr(50),
+	}}).ToTypedConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var overriddenLRL local_ratelimitv3.LocalRateLimit
+	if err := overridden.Any.UnmarshalTo(&overriddenLRL); err != nil {
+		t.Fatalf("unmarshaling LocalRateLimit: %v", err)
+	}
+	if got := overriddenLRL.FilterEnabled.GetDefaultValue().GetNumerator(); got != 50 {
+		t.Errorf("FilterEnabled numerator = %d, want overridden 50", got)
+	}
+	if overriddenLRL.FilterEnabled.GetDefaultValue().GetDenominator() != typev3.FractionalPercent_HUNDRED {
+		t.Errorf("FilterEnabled denominator = %v, want HUNDRED", overriddenLRL.FilterEnabled.GetDefaultValue().GetDenominator())
+	}
+}
+
+func uint32Ptr(u uint32) *uint32 { return &u }
@@ -0,0 +1,120 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Cilium
+
+package v2alpha1
+
+import (
+	"fmt"
+
+	corev3 "github.com/cilium/proxy/go/envoy/config/core/v3"
+	corsv3 "github.com/cilium/proxy/go/envoy/extensions/filters/http/cors/v3"
+	ext_authzv3 "github.com/cilium/proxy/go/envoy/extensions/filters/http/ext_authz/v3"
+	header_to_metadatav3 "github.com/cilium/proxy/go/envoy/extensions/filters/http/header_to_metadata/v3"
+	jwt_authnv3 "github.com/cilium/proxy/go/envoy/extensions/filters/http/jwt_authn/v3"
+	local_ratelimitv3 "github.com/cilium/proxy/go/envoy/extensions/filters/http/local_ratelimit/v3"
+	luav3 "github.com/cilium/proxy/go/envoy/extensions/filters/http/lua/v3"
+	rbacv3 "github.com/cilium/proxy/go/envoy/extensions/filters/http/rbac/v3"
+	routerv3 "github.com/cilium/proxy/go/envoy/extensions/filters/http/router/v3"
+	wasmv3 "github.com/cilium/proxy/go/envoy/extensions/filters/http/wasm/v3"
+	network_ext_authzv3 "github.com/cilium/proxy/go/envoy/extensions/filters/network/ext_authz/v3"
+	mongo_proxyv3 "github.com/cilium/proxy/go/envoy/extensions/filters/network/mongo_proxy/v3"
+	network_ratelimitv3 "github.com/cilium/proxy/go/envoy/extensions/filters/network/ratelimit/v3"
+	redis_proxyv3 "github.com/cilium/proxy/go/envoy/extensions/filters/network/redis_proxy/v3"
+	tcp_proxyv3 "github.com/cilium/proxy/go/envoy/extensions/filters/network/tcp_proxy/v3"
+	thrift_proxyv3 "github.com/cilium/proxy/go/envoy/extensions/filters/network/thrift_proxy/v3"
+	network_wasmv3 "github.com/cilium/proxy/go/envoy/extensions/filters/network/wasm/v3"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// typeAllowList is a curated allow-list of Envoy extension proto messages,
+// keyed by the typed_config "@type" URL, scoped to a single kind of payload
+// (HTTP filters, network filters, or extension config sources). Any `anypb.Any` whose type URL
+// isn't in the relevant list is rejected with an "unsupported filter" error
+// rather than a generic decode failure, so operators get a clear signal to
+// either correct a typo or ask for the filter to be added here.
+type typeAllowList map[string]func() proto.Message
+
+// httpFilterTypeAllowList covers the filters CiliumEnvoyHTTPFilter's
+// HTTPFilter.TypedConfig is willing to decode and validate.
+var httpFilterTypeAllowList = typeAllowList{
+	"type.googleapis.com/envoy.extensions.filters.http.jwt_authn.v3.JwtAuthentication":    func() proto.Message { return &jwt_authnv3.JwtAuthentication{} },
+	"type.googleapis.com/envoy.extensions.filters.http.rbac.v3.RBAC":                      func() proto.Message { return &rbacv3.RBAC{} },
+	"type.googleapis.com/envoy.extensions.filters.http.ext_authz.v3.ExtAuthz":             func() proto.Message { return &ext_authzv3.ExtAuthz{} },
+	"type.googleapis.com/envoy.extensions.filters.http.router.v3.Router":                  func() proto.Message { return &routerv3.Router{} },
+	"type.googleapis.com/envoy.extensions.filters.http.local_ratelimit.v3.LocalRateLimit": func() proto.Message { return &local_ratelimitv3.LocalRateLimit{} },
+	"type.googleapis.com/envoy.extensions.filters.http.lua.v3.Lua":                        func() proto.Message { return &luav3.Lua{} },
+	"type.googleapis.com/envoy.extensions.filters.http.cors.v3.Cors":                      func() proto.Message { return &corsv3.Cors{} },
+	"type.googleapis.com/envoy.extensions.filters.http.header_to_metadata.v3.Config":      func() proto.Message { return &header_to_metadatav3.Config{} },
+	"type.googleapis.com/envoy.extensions.filters.http.wasm.v3.Wasm":                      func() proto.Message { return &wasmv3.Wasm{} },
+}
+
+// networkFilterTypeAllowList covers the filters CiliumEnvoyNetworkFilter's
+// NetworkFilter.TypedConfig is willing to decode and validate.
+var networkFilterTypeAllowList = typeAllowList{
+	"type.googleapis.com/envoy.extensions.filters.network.tcp_proxy.v3.TcpProxy":     func() proto.Message { return &tcp_proxyv3.TcpProxy{} },
+	"type.googleapis.com/envoy.extensions.filters.network.mongo_proxy.v3.MongoProxy": func() proto.Message { return &mongo_proxyv3.MongoProxy{} },
+	"type.googleapis.com/envoy.extensions.filters.network.redis_proxy.v3.RedisProxy": func() proto.Message { return &redis_proxyv3.RedisProxy{} },
+	"type.googleapis.com/envoy.extensions.filters.network.thrift_proxy.v3.ThriftProxy": func() proto.Message {
+		return &thrift_proxyv3.ThriftProxy{}
+	},
+	"type.googleapis.com/envoy.extensions.filters.network.ratelimit.v3.RateLimit": func() proto.Message { return &network_ratelimitv3.RateLimit{} },
+	"type.googleapis.com/envoy.extensions.filters.network.ext_authz.v3.ExtAuthz":  func() proto.Message { return &network_ext_authzv3.ExtAuthz{} },
+	"type.googleapis.com/envoy.extensions.filters.network.wasm.v3.Wasm":           func() proto.Message { return &network_wasmv3.Wasm{} },
+}
+
+// extensionConfigSourceTypeAllowList covers the single message type that
+// ExtensionConfigSource (HTTPFilter/NetworkFilter ConfigDiscovery) stands in for.
+var extensionConfigSourceTypeAllowList = typeAllowList{
+	"type.googleapis.com/envoy.config.core.v3.ExtensionConfigSource": func() proto.Message { return &corev3.ExtensionConfigSource{} },
+}
+
+// validated is implemented by Envoy's PGV-generated (protoc-gen-validate)
+// message types.
+type validated interface {
+	Validate() error
+}
+
+// validateAny looks up the concrete Envoy message type registered for a's
+// type URL in allowList, unmarshals a into it, and runs its generated
+// Validate() method if it has one. It returns a precise error identifying
+// the offending type URL or field, suitable for surfacing from a
+// ValidatingAdmissionWebhook.
+func validateAny(a *anypb.Any, allowList typeAllowList) error {
+	if a == nil || a.TypeUrl == "" {
+		return fmt.Errorf("typed_config is empty")
+	}
+
+	newMessage, ok := allowList[a.TypeUrl]
+	if !ok {
+		return fmt.Errorf("unsupported filter type %q", a.TypeUrl)
+	}
+
+	msg := newMessage()
+	if err := a.UnmarshalTo(msg); err != nil {
+		return fmt.Errorf("decoding %q: %w", a.TypeUrl, err)
+	}
+
+	if v, ok := msg.(validated); ok {
+		if err := v.Validate(); err != nil {
+			return fmt.Errorf("invalid %q: %w", a.TypeUrl, err)
+		}
+	}
+
+	return nil
+}
+
+// Validate decodes the TypedConfig into its concrete Envoy HTTP filter
+// message type and runs the message's generated validation rules. It
+// returns an error for an empty TypedConfig, a type URL outside the HTTP
+// filter allow-list, a payload that fails to decode, or a failed rule.
+func (u *TypedConfig) Validate() error {
+	return validateAny(u.Any, httpFilterTypeAllowList)
+}
+
+// Validate decodes the ExtensionConfigSource into Envoy's
+// config.core.v3.ExtensionConfigSource and runs the message's generated
+// validation rules. See TypedConfig.Validate.
+func (u *ExtensionConfigSource) Validate() error {
+	return validateAny(u.Any, extensionConfigSourceTypeAllowList)
+}
@@ -0,0 +1,98 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Cilium
+
+package v2alpha1
+
+import (
+	"errors"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+var errBoom = errors.New("boom")
+
+// fakeValidated wraps a real, registered proto message so it round-trips
+// through anypb.New/UnmarshalTo, while adding a configurable Validate()
+// error to exercise the PGV-style validation path without depending on the
+// vendored Envoy protos.
+type fakeValidated struct {
+	wrapperspb.StringValue
+	err error
+}
+
+func (f *fakeValidated) Validate() error { return f.err }
+
+func TestValidateAny(t *testing.T) {
+	okAny, err := anypb.New(wrapperspb.String("ok"))
+	if err != nil {
+		t.Fatalf("anypb.New: %v", err)
+	}
+	typeURL := okAny.TypeUrl
+
+	tests := []struct {
+		name      string
+		any       *anypb.Any
+		allowList typeAllowList
+		wantErr   bool
+	}{
+		{
+			name:      "nil Any is rejected",
+			any:       nil,
+			allowList: typeAllowList{},
+			wantErr:   true,
+		},
+		{
+			name:      "unknown type URL is rejected",
+			any:       okAny,
+			allowList: typeAllowList{},
+			wantErr:   true,
+		},
+		{
+			name: "known type with no Validate() passes",
+			any:  okAny,
+			allowList: typeAllowList{
+				typeURL: func() proto.Message { return &wrapperspb.StringValue{} },
+			},
+			wantErr: false,
+		},
+		{
+			name: "known type whose Validate() passes",
+			any:  okAny,
+			allowList: typeAllowList{
+				typeURL: func() proto.Message { return &fakeValidated{} },
+			},
+			wantErr: false,
+		},
+		{
+			name: "known type whose Validate() fails is rejected",
+			any:  okAny,
+			allowList: typeAllowList{
+				typeURL: func() proto.Message { return &fakeValidated{err: errBoom} },
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateAny(tt.any, tt.allowList)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("validateAny() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestHTTPAndNetworkAllowListsAreDisjointByDesign(t *testing.T) {
+	// The HTTP and network filter-chain allow-lists are intentionally kept
+	// separate so an HTTP-only filter (e.g. jwt_authn) can never be
+	// accepted as a NetworkFilter, and vice versa for tcp_proxy et al.
+	for typeURL := range httpFilterTypeAllowList {
+		if _, ok := networkFilterTypeAllowList[typeURL]; ok {
+			t.Errorf("type URL %q is present in both allow-lists", typeURL)
+		}
+	}
+}